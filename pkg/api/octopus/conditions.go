@@ -0,0 +1,136 @@
+package octopus
+
+import (
+	"fmt"
+
+	oct "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+)
+
+// ConditionType identifies one aspect of a ClusterTestSuite's lifecycle, mirroring the
+// conditions design used by the k6-operator.
+type ConditionType string
+
+const (
+	// TestSuiteRunning is True while the suite's tests are being executed.
+	TestSuiteRunning ConditionType = "TestSuiteRunning"
+	// TestSuiteFinished is True once every test in the suite reached a terminal state.
+	TestSuiteFinished ConditionType = "TestSuiteFinished"
+	// TestSuiteErrored is True when the suite could not be executed, independent of individual test results.
+	TestSuiteErrored ConditionType = "TestSuiteErrored"
+	// AllTestsPassed is True when every test in the suite succeeded.
+	AllTestsPassed ConditionType = "AllTestsPassed"
+)
+
+// ConditionStatus is the tri-state value of a Condition.
+type ConditionStatus string
+
+const (
+	ConditionTrue    ConditionStatus = "True"
+	ConditionFalse   ConditionStatus = "False"
+	ConditionUnknown ConditionStatus = "Unknown"
+)
+
+// Condition reports the status of one aspect of a ClusterTestSuite's lifecycle.
+type Condition struct {
+	Type    ConditionType
+	Status  ConditionStatus
+	Reason  string
+	Message string
+}
+
+// SetCondition adds the condition to the suite's status, overwriting any existing condition of
+// the same type.
+func SetCondition(suite *oct.ClusterTestSuite, c Condition) {
+	conditions := getConditions(suite)
+	for i, existing := range conditions {
+		if existing.Type == c.Type {
+			conditions[i] = c
+			setConditions(suite, conditions)
+			return
+		}
+	}
+	setConditions(suite, append(conditions, c))
+}
+
+// UpdateCondition sets the status, reason and message of the condition of the given type,
+// adding it if it is not present yet.
+func UpdateCondition(suite *oct.ClusterTestSuite, t ConditionType, status ConditionStatus, reason, message string) {
+	SetCondition(suite, Condition{Type: t, Status: status, Reason: reason, Message: message})
+}
+
+// GetCondition returns the condition of the given type and whether it was found.
+func GetCondition(suite *oct.ClusterTestSuite, t ConditionType) (Condition, bool) {
+	for _, c := range getConditions(suite) {
+		if c.Type == t {
+			return c, true
+		}
+	}
+	return Condition{}, false
+}
+
+// IsTrue reports whether the condition of the given type is currently set to True.
+func IsTrue(suite *oct.ClusterTestSuite, t ConditionType) bool {
+	c, found := GetCondition(suite, t)
+	return found && c.Status == ConditionTrue
+}
+
+// DeriveConditions recomputes TestSuiteRunning, TestSuiteFinished and AllTestsPassed from the
+// suite's observed progress (total tests expected versus how many have reached a terminal
+// state, and how many of those failed). The Octopus controller itself has no notion of these
+// CLI-defined condition types, so callers must call this after every observation of the suite's
+// per-test results and persist it back with UpdateTestSuiteStatus for the conditions to reflect
+// reality instead of the one-time values seedInitialConditions set at creation time.
+func DeriveConditions(suite *oct.ClusterTestSuite, total, finished, failed int) {
+	done := total > 0 && finished >= total
+	if done {
+		UpdateCondition(suite, TestSuiteRunning, ConditionFalse, "AllTestsFinished", "")
+		UpdateCondition(suite, TestSuiteFinished, ConditionTrue, "AllTestsFinished", "")
+	} else {
+		UpdateCondition(suite, TestSuiteRunning, ConditionTrue, "TestsInProgress", fmt.Sprintf("%d/%d tests finished", finished, total))
+		UpdateCondition(suite, TestSuiteFinished, ConditionFalse, "TestsInProgress", "")
+	}
+
+	switch {
+	case !done:
+		UpdateCondition(suite, AllTestsPassed, ConditionUnknown, "TestsInProgress", "")
+	case failed == 0:
+		UpdateCondition(suite, AllTestsPassed, ConditionTrue, "AllTestsFinished", "")
+	default:
+		UpdateCondition(suite, AllTestsPassed, ConditionFalse, "AllTestsFinished", fmt.Sprintf("%d of %d tests failed", failed, total))
+	}
+}
+
+// MarkErrored sets TestSuiteErrored to True with the given reason and message. Unlike
+// DeriveConditions, this isn't derived from any individual test's result: it's for the CLI to
+// call when it observes that the suite itself failed to make progress, e.g. it timed out before
+// every test reached a terminal state.
+func MarkErrored(suite *oct.ClusterTestSuite, reason, message string) {
+	UpdateCondition(suite, TestSuiteErrored, ConditionTrue, reason, message)
+}
+
+func getConditions(suite *oct.ClusterTestSuite) []Condition {
+	raw := suite.Status.Conditions
+	conditions := make([]Condition, 0, len(raw))
+	for _, c := range raw {
+		conditions = append(conditions, Condition{
+			Type:    ConditionType(c.Type),
+			Status:  ConditionStatus(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+	return conditions
+}
+
+func setConditions(suite *oct.ClusterTestSuite, conditions []Condition) {
+	raw := make([]oct.ClusterTestSuiteCondition, 0, len(conditions))
+	for _, c := range conditions {
+		raw = append(raw, oct.ClusterTestSuiteCondition{
+			Type:    string(c.Type),
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		})
+	}
+	suite.Status.Conditions = raw
+}
@@ -0,0 +1,125 @@
+package octopus
+
+import (
+	"testing"
+
+	oct "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+)
+
+func TestSetCondition(t *testing.T) {
+	suite := &oct.ClusterTestSuite{}
+
+	SetCondition(suite, Condition{Type: TestSuiteRunning, Status: ConditionTrue, Reason: "Started"})
+	if len(suite.Status.Conditions) != 1 {
+		t.Fatalf("expected 1 condition, got %d", len(suite.Status.Conditions))
+	}
+
+	SetCondition(suite, Condition{Type: TestSuiteRunning, Status: ConditionFalse, Reason: "Finished"})
+	if len(suite.Status.Conditions) != 1 {
+		t.Fatalf("expected SetCondition to overwrite the existing condition instead of appending, got %d conditions", len(suite.Status.Conditions))
+	}
+	c, found := GetCondition(suite, TestSuiteRunning)
+	if !found || c.Status != ConditionFalse || c.Reason != "Finished" {
+		t.Fatalf("unexpected condition after overwrite: %+v", c)
+	}
+
+	SetCondition(suite, Condition{Type: TestSuiteFinished, Status: ConditionTrue})
+	if len(suite.Status.Conditions) != 2 {
+		t.Fatalf("expected a second condition of a different type to be appended, got %d", len(suite.Status.Conditions))
+	}
+}
+
+func TestUpdateCondition(t *testing.T) {
+	suite := &oct.ClusterTestSuite{}
+
+	UpdateCondition(suite, AllTestsPassed, ConditionUnknown, "SuiteCreated", "")
+	c, found := GetCondition(suite, AllTestsPassed)
+	if !found {
+		t.Fatal("expected UpdateCondition to add a missing condition")
+	}
+	if c.Status != ConditionUnknown || c.Reason != "SuiteCreated" {
+		t.Fatalf("unexpected condition: %+v", c)
+	}
+
+	UpdateCondition(suite, AllTestsPassed, ConditionTrue, "AllPassed", "every test passed")
+	c, found = GetCondition(suite, AllTestsPassed)
+	if !found || c.Status != ConditionTrue || c.Reason != "AllPassed" || c.Message != "every test passed" {
+		t.Fatalf("unexpected condition after update: %+v", c)
+	}
+}
+
+func TestGetCondition(t *testing.T) {
+	suite := &oct.ClusterTestSuite{}
+
+	if _, found := GetCondition(suite, AllTestsPassed); found {
+		t.Fatal("expected GetCondition to report not found on an empty suite")
+	}
+
+	SetCondition(suite, Condition{Type: AllTestsPassed, Status: ConditionFalse})
+	if _, found := GetCondition(suite, AllTestsPassed); !found {
+		t.Fatal("expected GetCondition to find a condition that was set")
+	}
+}
+
+func TestIsTrue(t *testing.T) {
+	suite := &oct.ClusterTestSuite{}
+
+	if IsTrue(suite, TestSuiteErrored) {
+		t.Fatal("expected IsTrue to report false for a condition that was never set")
+	}
+
+	SetCondition(suite, Condition{Type: TestSuiteErrored, Status: ConditionFalse})
+	if IsTrue(suite, TestSuiteErrored) {
+		t.Fatal("expected IsTrue to report false for a False condition")
+	}
+
+	SetCondition(suite, Condition{Type: TestSuiteErrored, Status: ConditionTrue})
+	if !IsTrue(suite, TestSuiteErrored) {
+		t.Fatal("expected IsTrue to report true for a True condition")
+	}
+}
+
+func TestMarkErrored(t *testing.T) {
+	suite := &oct.ClusterTestSuite{}
+
+	if IsTrue(suite, TestSuiteErrored) {
+		t.Fatal("expected TestSuiteErrored to be false before MarkErrored is called")
+	}
+
+	MarkErrored(suite, "Timeout", "suite did not finish within 30m0s")
+	c, found := GetCondition(suite, TestSuiteErrored)
+	if !found || c.Status != ConditionTrue || c.Reason != "Timeout" {
+		t.Fatalf("unexpected condition after MarkErrored: %+v", c)
+	}
+}
+
+func TestDeriveConditions(t *testing.T) {
+	suite := &oct.ClusterTestSuite{}
+
+	DeriveConditions(suite, 3, 1, 0)
+	if IsTrue(suite, TestSuiteFinished) {
+		t.Fatal("expected TestSuiteFinished to stay False while tests are still in progress")
+	}
+	if !IsTrue(suite, TestSuiteRunning) {
+		t.Fatal("expected TestSuiteRunning to be True while tests are still in progress")
+	}
+	if c, _ := GetCondition(suite, AllTestsPassed); c.Status != ConditionUnknown {
+		t.Fatalf("expected AllTestsPassed to stay Unknown while tests are still in progress, got %s", c.Status)
+	}
+
+	DeriveConditions(suite, 3, 3, 1)
+	if !IsTrue(suite, TestSuiteFinished) {
+		t.Fatal("expected TestSuiteFinished to become True once every test reached a terminal state")
+	}
+	if IsTrue(suite, TestSuiteRunning) {
+		t.Fatal("expected TestSuiteRunning to become False once every test reached a terminal state")
+	}
+	if c, _ := GetCondition(suite, AllTestsPassed); c.Status != ConditionFalse {
+		t.Fatalf("expected AllTestsPassed to be False when a test failed, got %s", c.Status)
+	}
+
+	DeriveConditions(suite, 3, 3, 0)
+	if c, _ := GetCondition(suite, AllTestsPassed); c.Status != ConditionTrue {
+		t.Fatalf("expected AllTestsPassed to be True when every test passed, got %s", c.Status)
+	}
+}
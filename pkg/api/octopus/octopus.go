@@ -0,0 +1,48 @@
+package octopus
+
+import (
+	oct "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	octopusClientset "github.com/kyma-incubator/octopus/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OctopusInterface abstracts access to the Octopus custom resources used for running Kyma cluster tests.
+type OctopusInterface interface {
+	ListTestDefinitions() (*oct.TestDefinitionList, error)
+	CreateTestSuite(suite *oct.ClusterTestSuite) error
+	ListTestSuites() (*oct.ClusterTestSuiteList, error)
+	GetTestSuite(name string) (*oct.ClusterTestSuite, error)
+	UpdateTestSuiteStatus(suite *oct.ClusterTestSuite) (*oct.ClusterTestSuite, error)
+}
+
+type client struct {
+	cs octopusClientset.Interface
+}
+
+// NewClient returns an OctopusInterface backed by the given Octopus clientset.
+func NewClient(cs octopusClientset.Interface) OctopusInterface {
+	return &client{cs: cs}
+}
+
+func (c *client) ListTestDefinitions() (*oct.TestDefinitionList, error) {
+	return c.cs.TestingV1alpha1().TestDefinitions(metav1.NamespaceAll).List(metav1.ListOptions{})
+}
+
+func (c *client) CreateTestSuite(suite *oct.ClusterTestSuite) error {
+	_, err := c.cs.TestingV1alpha1().ClusterTestSuites().Create(suite)
+	return err
+}
+
+func (c *client) ListTestSuites() (*oct.ClusterTestSuiteList, error) {
+	return c.cs.TestingV1alpha1().ClusterTestSuites().List(metav1.ListOptions{})
+}
+
+func (c *client) GetTestSuite(name string) (*oct.ClusterTestSuite, error) {
+	return c.cs.TestingV1alpha1().ClusterTestSuites().Get(name, metav1.GetOptions{})
+}
+
+// UpdateTestSuiteStatus persists the conditions and results already set on suite.Status back to
+// the API server, e.g. after DeriveConditions recomputed them from freshly observed progress.
+func (c *client) UpdateTestSuiteStatus(suite *oct.ClusterTestSuite) (*oct.ClusterTestSuite, error) {
+	return c.cs.TestingV1alpha1().ClusterTestSuites().UpdateStatus(suite)
+}
@@ -0,0 +1,16 @@
+package octopus
+
+import (
+	oct "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewTestSuite returns an empty ClusterTestSuite named name, ready for its Spec to be filled in
+// before being passed to CreateTestSuite.
+func NewTestSuite(name string) *oct.ClusterTestSuite {
+	return &oct.ClusterTestSuite{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
@@ -3,10 +3,15 @@ package asyncui
 import (
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/kyma-incubator/hydroform/parallel-install/pkg/components"
 	"github.com/kyma-incubator/hydroform/parallel-install/pkg/deployment"
 	"github.com/kyma-project/cli/pkg/step"
+	"github.com/kyma-project/cli/pkg/steps"
+	"github.com/pkg/errors"
+	"golang.org/x/term"
 )
 
 // StepFactory is a factory used to generate a step in the UI.
@@ -23,6 +28,17 @@ const (
 	deployComponentMsg            string = "Deploying component '%s'"
 )
 
+// PhaseResult captures how the readiness recheck of a component within an installation phase
+// concluded, including how often ComponentHealthCheck was retried before it succeeded or finally
+// failed.
+type PhaseResult struct {
+	Phase     deployment.InstallationPhase
+	Component string
+	Retries   int
+	LastError error
+	Failed    bool
+}
+
 // AsyncUI renders the CLI ui based on receiving events
 type AsyncUI struct {
 	// used to create UI steps
@@ -39,6 +55,28 @@ type AsyncUI struct {
 	running bool
 	// a failure occurred
 	Failed bool
+
+	// ComponentHealthCheck, when set, is used to recheck a component's readiness after an error
+	// is reported instead of failing the phase immediately. A nil check preserves the previous
+	// fail-fast behaviour.
+	//
+	// Nothing in this repository sets it yet: the install/deploy command that constructs an
+	// AsyncUI lives outside this series, so this is scaffolding for that follow-up to wire a real
+	// health check into. Until then every caller keeps the fail-fast behaviour described above.
+	ComponentHealthCheck func(comp components.KymaComponent) (bool, error)
+	// MaxRetries bounds how many failed ComponentHealthCheck attempts are allowed before the
+	// phase is marked as failed. Zero defaults to defaultMaxRetries rather than retrying forever.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry of ComponentHealthCheck. Zero defaults to
+	// the steps.Runner default.
+	RetryBackoff time.Duration
+
+	// phaseResults accumulates the retry outcome of every component rechecked through
+	// ComponentHealthCheck, keyed by "<phase>/<component>".
+	phaseResults map[string]*PhaseResult
+	// phaseStart records when each phase started, used to compute the duration reported in
+	// emitted stop events.
+	phaseStart map[deployment.InstallationPhase]time.Time
 }
 
 // Start renders the CLI UI and provides the channel for receiving events
@@ -46,6 +84,9 @@ func (ui *AsyncUI) Start() error {
 	if ui.running {
 		return fmt.Errorf("Duplicate call of start method detected")
 	}
+	if ui.StepFactory == nil {
+		ui.StepFactory = DefaultStepFactory()
+	}
 	ui.running = true
 
 	// process async process updates
@@ -75,6 +116,17 @@ func (ui *AsyncUI) Start() error {
 	return nil
 }
 
+// DefaultStepFactory renders an interactive spinner when stdout is a terminal, and falls back to
+// JSONEmitter otherwise so CI / non-TTY environments get one machine-readable event per line.
+// Callers that render steps outside of an AsyncUI (e.g. a command polling on its own) should use
+// this too, so their output follows the same TTY detection.
+func DefaultStepFactory() StepFactory {
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		return &step.Factory{}
+	}
+	return &JSONEmitter{}
+}
+
 // dispatchError will pass an error to the Caller
 func (ui *AsyncUI) dispatchError(err error) {
 	if err != nil {
@@ -86,14 +138,22 @@ func (ui *AsyncUI) dispatchError(err error) {
 	}
 }
 
-// Stop will close the update channel and wait until the the UI rendering is finished
-func (ui *AsyncUI) Stop() {
+// Stop will close the update channel, wait until the UI rendering is finished, and return the
+// retry outcome of every component that was rechecked through ComponentHealthCheck. Phases and
+// components that were not rechecked have no entry.
+func (ui *AsyncUI) Stop() []PhaseResult {
 	if !ui.running {
-		return
+		return nil
 	}
 	close(ui.updates)
 	<-ui.context.Done()
 	ui.running = false
+
+	results := make([]PhaseResult, 0, len(ui.phaseResults))
+	for _, r := range ui.phaseResults {
+		results = append(results, *r)
+	}
+	return results
 }
 
 // renderStartEvent dispatches an start event to an UI step
@@ -118,9 +178,39 @@ func (ui *AsyncUI) renderStartEvent(procUpdEvent deployment.ProcessUpdate, ongoi
 		stepMsg = string(procUpdEvent.Phase)
 	}
 	(*ongoingSteps)[procUpdEvent.Phase] = ui.StepFactory.NewStep(stepMsg)
+
+	if ui.phaseStart == nil {
+		ui.phaseStart = make(map[deployment.InstallationPhase]time.Time)
+	}
+	ui.phaseStart[procUpdEvent.Phase] = time.Now()
+	ui.emitEvent(procUpdEvent.Phase, "", "start", "", 0, nil)
 	return nil
 }
 
+// emitEvent reports a structured event to the StepFactory when it implements EventEmitter, e.g.
+// the JSONEmitter used in non-TTY environments. It is a no-op for factories that only render steps.
+func (ui *AsyncUI) emitEvent(phase deployment.InstallationPhase, component, eventName, status string, duration time.Duration, err error) {
+	emitter, ok := ui.StepFactory.(EventEmitter)
+	if !ok {
+		return
+	}
+
+	evt := Event{
+		Timestamp: time.Now(),
+		Phase:     string(phase),
+		Component: component,
+		Event:     eventName,
+		Status:    status,
+	}
+	if duration > 0 {
+		evt.DurationMs = duration.Milliseconds()
+	}
+	if err != nil {
+		evt.Err = err.Error()
+	}
+	emitter.EmitEvent(evt)
+}
+
 // renderStartEvent dispatches a stop event to an running step
 func (ui *AsyncUI) renderStopEvent(procUpdEvent deployment.ProcessUpdate, ongoingSteps *map[deployment.InstallationPhase]step.Step) error {
 	if _, exists := (*ongoingSteps)[procUpdEvent.Phase]; !exists {
@@ -131,28 +221,86 @@ func (ui *AsyncUI) renderStopEvent(procUpdEvent deployment.ProcessUpdate, ongoin
 	event := procUpdEvent.Event
 	installPhase := procUpdEvent.Phase
 
+	duration := time.Since(ui.phaseStart[installPhase])
+
 	// for events related to major installation phases (they don't contain a reference to a component) just stop the spinner
 	if comp.Name == "" {
 		if event == deployment.ProcessFinished {
 			//all good
 			(*ongoingSteps)[installPhase].Success()
+			ui.emitEvent(installPhase, "", "stop", "success", duration, nil)
 			return nil
 		}
 		//something went wrong
 		(*ongoingSteps)[installPhase].Failure()
-		return fmt.Errorf("Deployment phase '%s' failed: %s", installPhase, event)
+		err := fmt.Errorf("Deployment phase '%s' failed: %s", installPhase, event)
+		ui.emitEvent(installPhase, "", "stop", "failure", duration, err)
+		return err
 	}
 
 	// for component specific installation event show the result in a dedicated step
-	step := ui.StepFactory.NewStep(fmt.Sprintf(deployComponentMsg, comp.Name))
+	compStep := ui.StepFactory.NewStep(fmt.Sprintf(deployComponentMsg, comp.Name))
 	if comp.Status == components.StatusError {
-		step.Failure()
-		return fmt.Errorf("Deployment of component '%s' failed", comp.Name)
+		if ui.ComponentHealthCheck == nil {
+			compStep.Failure()
+			err := fmt.Errorf("Deployment of component '%s' failed", comp.Name)
+			ui.emitEvent(installPhase, comp.Name, "stop", "failure", duration, err)
+			return err
+		}
+
+		result := ui.retryComponentHealth(installPhase, comp)
+		if result.Failed {
+			compStep.Failure()
+			ui.emitEvent(installPhase, comp.Name, "stop", "failure", duration, result.LastError)
+			return errors.Wrapf(result.LastError, "Deployment of component '%s' failed after %d retries", comp.Name, result.Retries)
+		}
 	}
-	step.Success()
+	compStep.Success()
+	ui.emitEvent(installPhase, comp.Name, "stop", "success", duration, nil)
 	return nil
 }
 
+// retryComponentHealth rechecks a component's readiness through ui.ComponentHealthCheck, retrying
+// on transient failures up to ui.MaxRetries, and records the outcome under the owning phase.
+// defaultMaxRetries caps ComponentHealthCheck retries when the caller leaves MaxRetries unset, so
+// a persistently unhealthy component fails the phase instead of retrying forever.
+const defaultMaxRetries = 5
+
+func (ui *AsyncUI) retryComponentHealth(phase deployment.InstallationPhase, comp components.KymaComponent) PhaseResult {
+	maxRetries := ui.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+
+	runner := steps.Runner{
+		Steps: []steps.Step{
+			{
+				Name:       fmt.Sprintf("health-check-%s", comp.Name),
+				MaxRetries: maxRetries,
+				Backoff:    ui.RetryBackoff,
+				Condition: func() (bool, bool, error) {
+					ok, err := ui.ComponentHealthCheck(comp)
+					return ok, !ok, err
+				},
+			},
+		},
+	}
+
+	stepResults, err := runner.Run()
+	result := PhaseResult{Phase: phase, Component: comp.Name}
+	if len(stepResults) > 0 {
+		result.Retries = stepResults[0].Retries
+		result.LastError = stepResults[0].Err
+	}
+	result.Failed = err != nil
+
+	if ui.phaseResults == nil {
+		ui.phaseResults = make(map[string]*PhaseResult)
+	}
+	ui.phaseResults[fmt.Sprintf("%s/%s", phase, comp.Name)] = &result
+	return result
+}
+
 //AddStep adds an additional installation step
 func (ui *AsyncUI) AddStep(step string) (step.Step, error) {
 	if !ui.running {
@@ -0,0 +1,136 @@
+package asyncui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyma-incubator/hydroform/parallel-install/pkg/components"
+	"github.com/kyma-incubator/hydroform/parallel-install/pkg/deployment"
+	"github.com/kyma-project/cli/pkg/step"
+)
+
+// recordingFactory is a StepFactory/EventEmitter that hands out no-op steps and records every
+// emitted event, so tests can assert on what renderStopEvent reports without parsing JSON lines.
+type recordingFactory struct {
+	events []Event
+}
+
+func (f *recordingFactory) NewStep(msg string) step.Step { return noopStep{} }
+
+func (f *recordingFactory) EmitEvent(e Event) {
+	f.events = append(f.events, e)
+}
+
+type noopStep struct{}
+
+func (noopStep) Start()                                       {}
+func (noopStep) Status(msg string)                            {}
+func (noopStep) Success()                                     {}
+func (noopStep) Successf(format string, args ...interface{})  {}
+func (noopStep) Failure()                                     {}
+func (noopStep) Failuref(format string, args ...interface{})  {}
+func (noopStep) LogInfo(msg string)                           {}
+func (noopStep) LogInfof(format string, args ...interface{})  {}
+func (noopStep) LogError(msg string)                          {}
+func (noopStep) LogErrorf(format string, args ...interface{}) {}
+func (noopStep) LogWarn(msg string)                           {}
+func (noopStep) LogWarnf(format string, args ...interface{})  {}
+
+func TestRenderStopEventComponentHealthCheck(t *testing.T) {
+	newEvent := func() deployment.ProcessUpdate {
+		return deployment.ProcessUpdate{
+			Phase:     deployment.InstallComponents,
+			Component: components.KymaComponent{Name: "core", Status: components.StatusError},
+		}
+	}
+	newOngoingSteps := func(ui *AsyncUI) map[deployment.InstallationPhase]step.Step {
+		return map[deployment.InstallationPhase]step.Step{
+			deployment.InstallComponents: ui.StepFactory.NewStep("phase"),
+		}
+	}
+
+	t.Run("nil ComponentHealthCheck fails fast", func(t *testing.T) {
+		factory := &recordingFactory{}
+		ui := &AsyncUI{
+			StepFactory: factory,
+			phaseStart:  map[deployment.InstallationPhase]time.Time{deployment.InstallComponents: time.Now()},
+		}
+		ongoingSteps := newOngoingSteps(ui)
+
+		err := ui.renderStopEvent(newEvent(), &ongoingSteps)
+		if err == nil {
+			t.Fatal("expected an error when the component reports an error and no health check is configured")
+		}
+		if len(ui.phaseResults) != 0 {
+			t.Fatalf("expected no retry bookkeeping without a ComponentHealthCheck, got %+v", ui.phaseResults)
+		}
+
+		if len(factory.events) != 1 {
+			t.Fatalf("expected exactly one emitted event, got %d", len(factory.events))
+		}
+		if factory.events[0].Err != err.Error() {
+			t.Fatalf("expected the emitted event's err to carry the returned error %q, got %q", err.Error(), factory.events[0].Err)
+		}
+	})
+
+	t.Run("recovers after transient failures", func(t *testing.T) {
+		calls := 0
+		ui := &AsyncUI{
+			StepFactory:  &JSONEmitter{},
+			RetryBackoff: time.Millisecond,
+			phaseStart:   map[deployment.InstallationPhase]time.Time{deployment.InstallComponents: time.Now()},
+			ComponentHealthCheck: func(comp components.KymaComponent) (bool, error) {
+				calls++
+				return calls >= 3, nil
+			},
+		}
+		ongoingSteps := newOngoingSteps(ui)
+
+		if err := ui.renderStopEvent(newEvent(), &ongoingSteps); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 health check calls before recovering, got %d", calls)
+		}
+
+		result, ok := ui.phaseResults[string(deployment.InstallComponents)+"/core"]
+		if !ok {
+			t.Fatal("expected a recorded phase result for the retried component")
+		}
+		if result.Failed {
+			t.Fatalf("expected the phase result to report success, got %+v", result)
+		}
+		if result.Retries != 2 {
+			t.Fatalf("expected 2 retries before recovering, got %d", result.Retries)
+		}
+	})
+
+	t.Run("fails after exhausting retries", func(t *testing.T) {
+		ui := &AsyncUI{
+			StepFactory:  &JSONEmitter{},
+			MaxRetries:   2,
+			RetryBackoff: time.Millisecond,
+			phaseStart:   map[deployment.InstallationPhase]time.Time{deployment.InstallComponents: time.Now()},
+			ComponentHealthCheck: func(comp components.KymaComponent) (bool, error) {
+				return false, errors.New("still not ready")
+			},
+		}
+		ongoingSteps := newOngoingSteps(ui)
+
+		if err := ui.renderStopEvent(newEvent(), &ongoingSteps); err == nil {
+			t.Fatal("expected an error once the retry budget is exhausted")
+		}
+
+		result, ok := ui.phaseResults[string(deployment.InstallComponents)+"/core"]
+		if !ok {
+			t.Fatal("expected a recorded phase result for the exhausted component")
+		}
+		if !result.Failed {
+			t.Fatalf("expected the phase result to report failure, got %+v", result)
+		}
+		if result.Retries != ui.MaxRetries {
+			t.Fatalf("expected %d retries, got %d", ui.MaxRetries, result.Retries)
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package asyncui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kyma-project/cli/pkg/step"
+)
+
+// Event is the structured record emitted by JSONEmitter for every phase/component transition.
+type Event struct {
+	Timestamp  time.Time `json:"ts"`
+	Phase      string    `json:"phase"`
+	Component  string    `json:"component,omitempty"`
+	Event      string    `json:"event"`
+	Status     string    `json:"status,omitempty"`
+	DurationMs int64     `json:"durationMs,omitempty"`
+	Err        string    `json:"err,omitempty"`
+}
+
+// EventEmitter is implemented by StepFactory implementations that want structured per-event
+// callbacks in addition to (or instead of) rendering a step, e.g. to emit JSON lines for CI
+// consumption.
+type EventEmitter interface {
+	EmitEvent(e Event)
+}
+
+// JSONEmitter is a StepFactory for non-TTY / CI environments: instead of rendering a spinner it
+// writes one JSON object per line to stdout for every deployment.ProcessUpdate event, the
+// machine-readable equivalent of the interactive spinner output.
+type JSONEmitter struct{}
+
+// NewStep returns a step that reports its own progress as JSON lines on Success/Failure, so
+// callers driving a step.Step directly (not just AsyncUI, e.g. `kyma test run --wait`) still get
+// machine-readable output in non-TTY environments.
+func (e *JSONEmitter) NewStep(msg string) step.Step {
+	return &jsonStep{message: msg, start: time.Now()}
+}
+
+// EmitEvent writes evt to stdout as a single line of JSON.
+func (e *JSONEmitter) EmitEvent(evt Event) {
+	emitJSONLine(evt)
+}
+
+func emitJSONLine(v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to marshal event: %s\n", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// stepEvent is the JSON line emitted by jsonStep for a single step's terminal state or logged
+// message.
+type stepEvent struct {
+	Timestamp  time.Time `json:"ts"`
+	Message    string    `json:"message"`
+	Status     string    `json:"status"`
+	DurationMs int64     `json:"durationMs"`
+	Detail     string    `json:"detail,omitempty"`
+}
+
+// jsonStep implements step.Step by emitting one stepEvent line per terminal transition and per
+// logged message, instead of rendering a spinner.
+type jsonStep struct {
+	message string
+	start   time.Time
+}
+
+func (s *jsonStep) Start() { s.start = time.Now() }
+
+func (s *jsonStep) Status(msg string) {}
+
+func (s *jsonStep) Success() { s.emit("success", "") }
+
+func (s *jsonStep) Successf(format string, args ...interface{}) {
+	s.emit("success", fmt.Sprintf(format, args...))
+}
+
+func (s *jsonStep) Failure() { s.emit("failure", "") }
+
+func (s *jsonStep) Failuref(format string, args ...interface{}) {
+	s.emit("failure", fmt.Sprintf(format, args...))
+}
+
+func (s *jsonStep) LogInfo(msg string) { s.emit("info", msg) }
+
+func (s *jsonStep) LogInfof(format string, args ...interface{}) {
+	s.emit("info", fmt.Sprintf(format, args...))
+}
+
+func (s *jsonStep) LogError(msg string) { s.emit("error", msg) }
+
+func (s *jsonStep) LogErrorf(format string, args ...interface{}) {
+	s.emit("error", fmt.Sprintf(format, args...))
+}
+
+func (s *jsonStep) LogWarn(msg string) { s.emit("warn", msg) }
+
+func (s *jsonStep) LogWarnf(format string, args ...interface{}) {
+	s.emit("warn", fmt.Sprintf(format, args...))
+}
+
+func (s *jsonStep) emit(status, detail string) {
+	emitJSONLine(stepEvent{
+		Timestamp:  time.Now(),
+		Message:    s.message,
+		Status:     status,
+		DurationMs: time.Since(s.start).Milliseconds(),
+		Detail:     detail,
+	})
+}
@@ -0,0 +1,23 @@
+package test
+
+import (
+	"github.com/kyma-project/cli/pkg/kyma/cmd/test/run"
+	"github.com/kyma-project/cli/pkg/kyma/cmd/test/status"
+	"github.com/kyma-project/cli/pkg/kyma/core"
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates a new `test` command which groups the subcommands for running and inspecting
+// tests on a Kyma cluster.
+func NewCmd(o *core.Options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "test",
+		Short:   "Runs and inspects tests on a Kyma cluster",
+		Long:    "Runs and inspects tests on a Kyma cluster using the Octopus test framework.",
+		Aliases: []string{"t"},
+	}
+
+	cmd.AddCommand(run.NewCmd(run.NewOptions(o)))
+	cmd.AddCommand(status.NewCmd(status.NewOptions(o)))
+	return cmd
+}
@@ -9,7 +9,6 @@ import (
 	oct "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
 	"github.com/kyma-project/cli/internal/kube"
 	"github.com/kyma-project/cli/pkg/api/octopus"
-	"github.com/kyma-project/cli/pkg/kyma/cmd/test"
 	"github.com/kyma-project/cli/pkg/kyma/core"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -45,10 +44,22 @@ kyma test run -n example-test
 	cobraCmd.Flags().Int64VarP(&o.ExecutionCount, "count", "c", 1, "Number of execution rounds for each test in the suite. You cannot configure this value in parallel with max-retries")
 	cobraCmd.Flags().Int64VarP(&o.MaxRetries, "max-retries", "", 1, "Number of retries for a failed test.")
 	cobraCmd.Flags().Int64VarP(&o.Concurrency, "concurrency", "", 1, "Number of tests to be executed in parallel.")
+	cobraCmd.Flags().BoolVarP(&o.Wait, "wait", "w", false, "Wait until the test suite reaches a terminal state before returning.")
+	cobraCmd.Flags().DurationVarP(&o.Timeout, "timeout", "t", 30*time.Minute, "Maximum time to wait for the test suite to finish. Only applies when --wait is set.")
+	cobraCmd.Flags().StringVar(&o.OutputDir, "output-dir", "", "Directory to store the test pod logs and reports in. Only applies when --wait is set.")
+	cobraCmd.Flags().StringVar(&o.Output, "output", outputText, "Output format for the test results. One of: text|json|junit. Only applies when --wait is set.")
+	cobraCmd.Flags().StringArrayVar(&o.Fetch, "fetch", nil, "Copy files matching the glob from finished test pods into a local directory. Repeatable. Format: glob[:dest], dest defaults to the current directory. Implies --wait.")
 	return cobraCmd
 }
 
 func (cmd *command) Run(args []string) error {
+	if len(cmd.opts.Fetch) > 0 && !cmd.opts.Wait {
+		// artifacts only exist once the suite has finished, so --fetch has nothing to act on
+		// without --wait; rather than silently doing nothing, wait on the user's behalf.
+		fmt.Println("--fetch requires waiting for the test suite to finish, enabling --wait")
+		cmd.opts.Wait = true
+	}
+
 	var err error
 	if cmd.K8s, err = kube.NewFromConfig("", cmd.KubeconfigPath); err != nil {
 		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid.")
@@ -98,6 +109,33 @@ func (cmd *command) Run(args []string) error {
 	}
 
 	fmt.Printf("test suite '%s' successfully created\r\n", testSuiteName)
+
+	if !cmd.opts.Wait {
+		return nil
+	}
+
+	result, suite, err := cmd.waitForSuite(testSuiteName, testDefToApply)
+	if err != nil {
+		return err
+	}
+
+	if len(cmd.opts.Fetch) > 0 {
+		specs, err := parseFetchFlags(cmd.opts.Fetch)
+		if err != nil {
+			return err
+		}
+		if _, err := cmd.fetchArtifacts(suite, specs); err != nil {
+			return err
+		}
+	}
+
+	if err := cmd.writeReport(result); err != nil {
+		return err
+	}
+
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d tests in suite '%s' failed", result.Failed, result.Total, testSuiteName)
+	}
 	return nil
 }
 
@@ -124,7 +162,7 @@ func generateTestsResource(testName string, numberOfExecutions,
 	maxRetries, concurrency int64,
 	testDefinitions []oct.TestDefinition) *oct.ClusterTestSuite {
 
-	octTestDefs := test.NewTestSuite(testName)
+	octTestDefs := octopus.NewTestSuite(testName)
 	matchNames := []oct.TestDefReference{}
 	for _, td := range testDefinitions {
 		matchNames = append(matchNames, oct.TestDefReference{
@@ -137,9 +175,20 @@ func generateTestsResource(testName string, numberOfExecutions,
 	octTestDefs.Spec.Count = numberOfExecutions
 	octTestDefs.Spec.Selectors.MatchNames = matchNames
 
+	seedInitialConditions(octTestDefs)
+
 	return octTestDefs
 }
 
+// seedInitialConditions sets every known condition to its starting value so that `kyma test
+// status` has a stable contract to read from the moment the suite is created.
+func seedInitialConditions(suite *oct.ClusterTestSuite) {
+	octopus.UpdateCondition(suite, octopus.TestSuiteRunning, octopus.ConditionUnknown, "SuiteCreated", "Waiting for the test suite controller to pick up the suite.")
+	octopus.UpdateCondition(suite, octopus.TestSuiteFinished, octopus.ConditionFalse, "SuiteCreated", "")
+	octopus.UpdateCondition(suite, octopus.TestSuiteErrored, octopus.ConditionFalse, "SuiteCreated", "")
+	octopus.UpdateCondition(suite, octopus.AllTestsPassed, octopus.ConditionUnknown, "SuiteCreated", "")
+}
+
 func listTestSuiteNames(cli octopus.OctopusInterface) ([]string, error) {
 	suites, err := cli.ListTestSuites()
 	if err != nil {
@@ -165,4 +214,4 @@ func verifyIfTestNotExists(suiteName string,
 		}
 	}
 	return true, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,302 @@
+package run
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	oct "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+const debugContainerName = "kyma-fetch-debugger"
+
+type fetchSpec struct {
+	Glob string
+	Dest string
+}
+
+type fetchedArtifact struct {
+	Test   string
+	Path   string
+	Size   int64
+	SHA256 string
+}
+
+// parseFetchFlags turns repeated --fetch glob[:dest] values into fetchSpecs, defaulting dest to
+// the current directory.
+func parseFetchFlags(values []string) ([]fetchSpec, error) {
+	specs := make([]fetchSpec, 0, len(values))
+	for _, v := range values {
+		parts := strings.SplitN(v, ":", 2)
+		spec := fetchSpec{Glob: parts[0], Dest: "."}
+		if len(parts) == 2 && parts[1] != "" {
+			spec.Dest = parts[1]
+		}
+		if spec.Glob == "" {
+			return nil, fmt.Errorf("Invalid --fetch value '%s', expected format glob[:dest]", v)
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+// fetchArtifacts copies files matching specs out of every finished test pod, using up to
+// --concurrency workers. A failure fetching from one pod is logged and does not abort the fetch
+// of the others.
+func (cmd *command) fetchArtifacts(suite *oct.ClusterTestSuite, specs []fetchSpec) ([]fetchedArtifact, error) {
+	restCfg, err := cmd.K8s.RestConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to get the Kubernetes REST config")
+	}
+
+	concurrency := int(cmd.opts.Concurrency)
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan oct.TestResult)
+	var mu sync.Mutex
+	var all []fetchedArtifact
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for tr := range jobs {
+				artifacts, err := cmd.fetchFromTest(restCfg, suite.GetName(), tr, specs)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Unable to fetch artifacts for test '%s': %s\n", tr.Name, err)
+					continue
+				}
+				mu.Lock()
+				all = append(all, artifacts...)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, tr := range suite.Status.Results {
+		jobs <- tr
+	}
+	close(jobs)
+	wg.Wait()
+
+	printArtifactSummary(all)
+	return all, nil
+}
+
+// fetchFromTest locates the pod that ran the given test and copies out every file matching specs.
+func (cmd *command) fetchFromTest(restCfg *rest.Config, suiteName string, tr oct.TestResult, specs []fetchSpec) ([]fetchedArtifact, error) {
+	pod, err := cmd.findExecTarget(suiteName, tr.Namespace, tr.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []fetchedArtifact
+	for _, spec := range specs {
+		fetched, err := cmd.fetchSpec(restCfg, pod, tr.Name, spec)
+		if err != nil {
+			return artifacts, err
+		}
+		artifacts = append(artifacts, fetched...)
+	}
+	return artifacts, nil
+}
+
+// fetchSpec copies every file matching a single glob out of pod into spec.Dest.
+func (cmd *command) fetchSpec(restCfg *rest.Config, pod v1.Pod, testName string, spec fetchSpec) ([]fetchedArtifact, error) {
+	var listOut bytes.Buffer
+	if err := cmd.execInPod(restCfg, pod, []string{"sh", "-c", fmt.Sprintf("ls -1 %s 2>/dev/null", spec.Glob)}, &listOut); err != nil {
+		return nil, errors.Wrapf(err, "Unable to list files matching '%s' in pod '%s'", spec.Glob, pod.Name)
+	}
+
+	remotePaths := strings.Fields(listOut.String())
+	if len(remotePaths) == 0 {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(spec.Dest, 0755); err != nil {
+		return nil, errors.Wrapf(err, "Unable to create destination directory '%s'", spec.Dest)
+	}
+
+	artifacts := make([]fetchedArtifact, 0, len(remotePaths))
+	for _, remotePath := range remotePaths {
+		artifact, err := cmd.copyFile(restCfg, pod, testName, remotePath, spec.Dest)
+		if err != nil {
+			return artifacts, err
+		}
+		artifacts = append(artifacts, artifact)
+	}
+	return artifacts, nil
+}
+
+// copyFile streams a single remote file out of pod and records its size and checksum.
+func (cmd *command) copyFile(restCfg *rest.Config, pod v1.Pod, testName, remotePath, destDir string) (fetchedArtifact, error) {
+	localPath := filepath.Join(destDir, fmt.Sprintf("%s-%s", testName, filepath.Base(remotePath)))
+
+	f, err := os.Create(localPath)
+	if err != nil {
+		return fetchedArtifact{}, errors.Wrapf(err, "Unable to create local file '%s'", localPath)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if err := cmd.execInPod(restCfg, pod, []string{"cat", remotePath}, io.MultiWriter(f, hasher)); err != nil {
+		return fetchedArtifact{}, errors.Wrapf(err, "Unable to copy '%s' from pod '%s'", remotePath, pod.Name)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return fetchedArtifact{}, err
+	}
+
+	return fetchedArtifact{
+		Test:   testName,
+		Path:   localPath,
+		Size:   info.Size(),
+		SHA256: fmt.Sprintf("%x", hasher.Sum(nil)),
+	}, nil
+}
+
+// execInPod runs command in the pod's first container over SPDY and streams its stdout to out.
+func (cmd *command) execInPod(restCfg *rest.Config, pod v1.Pod, command []string, out io.Writer) error {
+	req := cmd.K8s.Static().CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		VersionedParams(&v1.PodExecOptions{
+			Container: pod.Spec.Containers[0].Name,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(restCfg, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	return executor.Stream(remotecommand.StreamOptions{Stdout: out, Stderr: ioutil.Discard})
+}
+
+// findExecTarget returns a pod that can be exec'd into for the given test. If the test pod has
+// already exited, an ephemeral debug container mounting the same volumes is attached so artifacts
+// written to those volumes can still be copied out.
+func (cmd *command) findExecTarget(suiteName, namespace, testName string) (v1.Pod, error) {
+	pods, err := cmd.K8s.Static().CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("testing.kyma-project.io/def-name=%s,testing.kyma-project.io/suite-name=%s", testName, suiteName),
+	})
+	if err != nil {
+		return v1.Pod{}, err
+	}
+	if len(pods.Items) == 0 {
+		return v1.Pod{}, fmt.Errorf("no pod found for test '%s'", testName)
+	}
+
+	pod := pods.Items[0]
+	if pod.Status.Phase == v1.PodRunning {
+		return pod, nil
+	}
+	return cmd.attachDebugContainer(pod)
+}
+
+// attachDebugContainer adds an ephemeral container to an already-exited pod and waits for it to
+// start, so execInPod has a running container to target. Since the original container is no
+// longer running, a shared process namespace can't reach its filesystem; instead the debug
+// container mounts the same volumes, so it can reach anything the test wrote to a mounted
+// directory (e.g. a shared emptyDir) rather than the container's own root filesystem.
+func (cmd *command) attachDebugContainer(pod v1.Pod) (v1.Pod, error) {
+	for _, c := range pod.Spec.EphemeralContainers {
+		if c.Name == debugContainerName {
+			if err := cmd.waitForDebugContainer(pod.Namespace, pod.Name); err != nil {
+				return v1.Pod{}, err
+			}
+			return withDebugContainerAsTarget(pod), nil
+		}
+	}
+
+	patched := pod.DeepCopy()
+	patched.Spec.EphemeralContainers = append(patched.Spec.EphemeralContainers, buildDebugContainer(pod))
+
+	updated, err := cmd.K8s.Static().CoreV1().Pods(pod.Namespace).UpdateEphemeralContainers(pod.Name, patched, metav1.UpdateOptions{})
+	if err != nil {
+		return v1.Pod{}, errors.Wrapf(err, "Unable to attach a debug container to pod '%s'", pod.Name)
+	}
+
+	if err := cmd.waitForDebugContainer(pod.Namespace, pod.Name); err != nil {
+		return v1.Pod{}, err
+	}
+
+	return withDebugContainerAsTarget(*updated), nil
+}
+
+// withDebugContainerAsTarget points pod at the already-running debug container so execInPod
+// execs into it instead of the original, no-longer-running test container.
+func withDebugContainerAsTarget(pod v1.Pod) v1.Pod {
+	pod.Spec.Containers = []v1.Container{{Name: debugContainerName}}
+	return pod
+}
+
+// buildDebugContainer returns the ephemeral container to attach to pod, mounting the same
+// volumes as its first container so it can reach files the test wrote there even after the
+// original container exited.
+func buildDebugContainer(pod v1.Pod) v1.EphemeralContainer {
+	return v1.EphemeralContainer{
+		EphemeralContainerCommon: v1.EphemeralContainerCommon{
+			Name:         debugContainerName,
+			Image:        "busybox",
+			Command:      []string{"sleep", "300"},
+			VolumeMounts: pod.Spec.Containers[0].VolumeMounts,
+		},
+		TargetContainerName: pod.Spec.Containers[0].Name,
+	}
+}
+
+func (cmd *command) waitForDebugContainer(namespace, podName string) error {
+	const pollInterval = 2 * time.Second
+	deadline := time.Now().Add(60 * time.Second)
+	for {
+		pod, err := cmd.K8s.Static().CoreV1().Pods(namespace).Get(podName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		for _, cs := range pod.Status.EphemeralContainerStatuses {
+			if cs.Name == debugContainerName && cs.State.Running != nil {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out waiting for the debug container to start in pod '%s'", podName)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func printArtifactSummary(artifacts []fetchedArtifact) {
+	if len(artifacts) == 0 {
+		fmt.Println("No artifacts were fetched.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TEST\tPATH\tSIZE\tSHA256")
+	for _, a := range artifacts {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", a.Test, a.Path, a.Size, a.SHA256)
+	}
+	w.Flush()
+}
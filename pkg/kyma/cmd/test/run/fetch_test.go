@@ -0,0 +1,105 @@
+package run
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestParseFetchFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []fetchSpec
+		wantErr bool
+	}{
+		{
+			name:   "glob only defaults dest to the current directory",
+			values: []string{"*.log"},
+			want:   []fetchSpec{{Glob: "*.log", Dest: "."}},
+		},
+		{
+			name:   "glob and dest",
+			values: []string{"*.log:/tmp/out"},
+			want:   []fetchSpec{{Glob: "*.log", Dest: "/tmp/out"}},
+		},
+		{
+			name:   "trailing colon defaults dest to the current directory",
+			values: []string{"*.log:"},
+			want:   []fetchSpec{{Glob: "*.log", Dest: "."}},
+		},
+		{
+			name:   "multiple values",
+			values: []string{"*.log:/tmp/logs", "*.json:/tmp/json"},
+			want: []fetchSpec{
+				{Glob: "*.log", Dest: "/tmp/logs"},
+				{Glob: "*.json", Dest: "/tmp/json"},
+			},
+		},
+		{
+			name:    "missing glob is rejected",
+			values:  []string{":/tmp/out"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFetchFlags(tt.values)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithDebugContainerAsTarget(t *testing.T) {
+	pod := v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "test"}},
+			EphemeralContainers: []v1.EphemeralContainer{
+				{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: debugContainerName}},
+			},
+		},
+	}
+
+	updated := withDebugContainerAsTarget(pod)
+
+	if len(updated.Spec.Containers) != 1 || updated.Spec.Containers[0].Name != debugContainerName {
+		t.Fatalf("expected the pod to target the debug container '%s', got %+v", debugContainerName, updated.Spec.Containers)
+	}
+}
+
+func TestBuildDebugContainerMountsTestVolumes(t *testing.T) {
+	pod := v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: "test",
+					VolumeMounts: []v1.VolumeMount{
+						{Name: "artifacts", MountPath: "/artifacts"},
+					},
+				},
+			},
+		},
+	}
+
+	debug := buildDebugContainer(pod)
+
+	if debug.TargetContainerName != "test" {
+		t.Fatalf("expected the debug container to target 'test', got '%s'", debug.TargetContainerName)
+	}
+	if !reflect.DeepEqual(debug.VolumeMounts, pod.Spec.Containers[0].VolumeMounts) {
+		t.Fatalf("expected the debug container to mount the test container's volumes, got %+v", debug.VolumeMounts)
+	}
+}
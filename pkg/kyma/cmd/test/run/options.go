@@ -0,0 +1,32 @@
+package run
+
+import (
+	"time"
+
+	"github.com/kyma-project/cli/pkg/kyma/core"
+)
+
+// supported values for the --output flag
+const (
+	outputText  = "text"
+	outputJSON  = "json"
+	outputJUnit = "junit"
+)
+
+type options struct {
+	*core.Options
+	Name           string
+	ExecutionCount int64
+	MaxRetries     int64
+	Concurrency    int64
+	Wait           bool
+	Timeout        time.Duration
+	OutputDir      string
+	Output         string
+	Fetch          []string
+}
+
+// NewOptions creates options with default values
+func NewOptions(o *core.Options) *options {
+	return &options{Options: o}
+}
@@ -0,0 +1,117 @@
+package run
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// writeReport persists the test pod logs under --output-dir (when set) and reports the test results
+// in the format requested through --output: text and json are printed to stdout, junit is written
+// to junit.xml under --output-dir (or the current directory) and only its path is printed.
+func (cmd *command) writeReport(result *suiteResult) error {
+	if cmd.opts.OutputDir != "" {
+		if err := os.MkdirAll(cmd.opts.OutputDir, 0755); err != nil {
+			return errors.Wrap(err, "Unable to create the output directory")
+		}
+		for _, t := range result.Tests {
+			logFile := filepath.Join(cmd.opts.OutputDir, fmt.Sprintf("%s.log", t.Name))
+			if err := os.WriteFile(logFile, []byte(t.Log), 0644); err != nil {
+				return errors.Wrapf(err, "Unable to write the log file for test '%s'", t.Name)
+			}
+		}
+	}
+
+	switch cmd.opts.Output {
+	case outputJSON:
+		return cmd.writeJSONReport(result)
+	case outputJUnit:
+		return cmd.writeJUnitReport(result)
+	default:
+		return cmd.writeTextReport(result)
+	}
+}
+
+func (cmd *command) writeTextReport(result *suiteResult) error {
+	for _, t := range result.Tests {
+		fmt.Printf("%s\t%s\n", t.Status, t.Name)
+	}
+	fmt.Printf("\n%d tests, %d failed\n", result.Total, result.Failed)
+	return nil
+}
+
+func (cmd *command) writeJSONReport(result *suiteResult) error {
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Unable to marshal the test results")
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// JUnit XML model consumed by CI systems such as Jenkins and Prow.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+func (cmd *command) writeJUnitReport(result *suiteResult) error {
+	suite := junitTestSuite{Name: result.Suite, Tests: result.Total, Failures: result.Failed}
+	for _, t := range result.Tests {
+		tc := junitTestCase{Name: t.Name, ClassName: result.Suite, Time: t.Duration.Seconds()}
+		if t.Status == statusFailed {
+			tc.Failure = &junitFailure{Message: "test failed", Content: tailLines(t.Log, 50)}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Unable to marshal the JUnit report")
+	}
+
+	dir := cmd.opts.OutputDir
+	if dir == "" {
+		dir = "."
+	}
+	path := filepath.Join(dir, "junit.xml")
+	if err := os.WriteFile(path, append([]byte(xml.Header), data...), 0644); err != nil {
+		return errors.Wrapf(err, "Unable to write the JUnit report to '%s'", path)
+	}
+
+	fmt.Printf("JUnit report written to '%s'\n", path)
+	return nil
+}
+
+// tailLines returns the last n lines of log, or the whole log if it has fewer than n lines.
+func tailLines(log string, n int) string {
+	lines := strings.Split(strings.TrimRight(log, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,70 @@
+package run
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteJUnitReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kyma-test-run-junit")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cmd := &command{opts: &options{OutputDir: dir}}
+	result := &suiteResult{
+		Suite:  "example-suite",
+		Total:  2,
+		Failed: 1,
+		Tests: []testResult{
+			{Name: "passing-test", Status: statusSuccess, Duration: 2500 * time.Millisecond},
+			{Name: "failing-test", Status: statusFailed, Log: "boom", Duration: 1200 * time.Millisecond},
+		},
+	}
+
+	if err := cmd.writeJUnitReport(result); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "junit.xml"))
+	if err != nil {
+		t.Fatalf("unable to read junit.xml: %s", err)
+	}
+
+	var report junitTestSuites
+	if err := xml.Unmarshal(data, &report); err != nil {
+		t.Fatalf("unable to unmarshal junit.xml: %s", err)
+	}
+
+	if len(report.Suites) != 1 {
+		t.Fatalf("expected 1 suite, got %d", len(report.Suites))
+	}
+	suite := report.Suites[0]
+	if suite.Tests != 2 || suite.Failures != 1 {
+		t.Fatalf("unexpected suite totals: %+v", suite)
+	}
+	if len(suite.Cases) != 2 {
+		t.Fatalf("expected 2 test cases, got %d", len(suite.Cases))
+	}
+
+	passing := suite.Cases[0]
+	if passing.Time != 2.5 {
+		t.Fatalf("expected the passing test's elapsed time to be 2.5s, got %v", passing.Time)
+	}
+	if passing.Failure != nil {
+		t.Fatalf("expected the passing test to have no failure block, got %+v", passing.Failure)
+	}
+
+	failing := suite.Cases[1]
+	if failing.Time != 1.2 {
+		t.Fatalf("expected the failing test's elapsed time to be 1.2s, got %v", failing.Time)
+	}
+	if failing.Failure == nil || failing.Failure.Content != "boom" {
+		t.Fatalf("expected the failing test's log to be in the failure block, got %+v", failing.Failure)
+	}
+}
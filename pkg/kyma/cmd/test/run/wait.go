@@ -0,0 +1,179 @@
+package run
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	oct "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-project/cli/pkg/api/octopus"
+	"github.com/kyma-project/cli/pkg/asyncui"
+	"github.com/kyma-project/cli/pkg/step"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// terminal per-test statuses reported on ClusterTestSuite.Status.Results
+const (
+	statusSuccess = "Success"
+	statusFailed  = "Failure"
+)
+
+type testResult struct {
+	Name     string
+	Status   string
+	Log      string
+	Duration time.Duration
+}
+
+type suiteResult struct {
+	Suite  string
+	Tests  []testResult
+	Total  int
+	Failed int
+}
+
+// waitForSuite polls the ClusterTestSuite until every test reaches a terminal state or the configured
+// timeout elapses, rendering per-test progress through the asyncui step factory. It also returns the
+// last observed suite so callers can act on its per-test results, e.g. to fetch artifacts.
+func (cmd *command) waitForSuite(suiteName string, testDefs []oct.TestDefinition) (*suiteResult, *oct.ClusterTestSuite, error) {
+	factory := asyncui.DefaultStepFactory()
+	steps := make(map[string]step.Step, len(testDefs))
+	for _, td := range testDefs {
+		steps[td.GetName()] = factory.NewStep(fmt.Sprintf("Running test '%s'", td.GetName()))
+	}
+
+	deadline := time.Now().Add(cmd.opts.Timeout)
+	backoff := time.Second
+	const maxBackoff = 15 * time.Second
+
+	// testStart records when each test was first observed, so its elapsed time can be measured
+	// from the moment it actually started rather than from when the whole batch of steps was
+	// created.
+	testStart := make(map[string]time.Time, len(testDefs))
+	// testDuration records each test's elapsed time as soon as it reaches a terminal state, so
+	// later tests still being polled don't inflate the duration of ones that already finished.
+	testDuration := make(map[string]time.Duration, len(testDefs))
+	// finished and failed track how many tests have reached a terminal state across polls, so
+	// DeriveConditions can be fed the suite's real progress instead of the one-time seeded values.
+	finished, failed := 0, 0
+
+	var suite *oct.ClusterTestSuite
+	for {
+		var err error
+		suite, err = cmd.K8s.Octopus().GetTestSuite(suiteName)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "Unable to get the status of the test suite")
+		}
+
+		for _, tr := range suite.Status.Results {
+			s, exists := steps[tr.Name]
+
+			start, seen := testStart[tr.Name]
+			if !seen {
+				start = time.Now()
+				testStart[tr.Name] = start
+				if exists {
+					s.Start()
+				}
+			}
+
+			if !exists {
+				continue
+			}
+			switch tr.Status {
+			case statusSuccess:
+				s.Success()
+				testDuration[tr.Name] = time.Since(start)
+				delete(steps, tr.Name)
+				finished++
+			case statusFailed:
+				s.Failure()
+				testDuration[tr.Name] = time.Since(start)
+				delete(steps, tr.Name)
+				finished++
+				failed++
+			}
+		}
+
+		octopus.DeriveConditions(suite, len(testDefs), finished, failed)
+		if suite, err = cmd.K8s.Octopus().UpdateTestSuiteStatus(suite); err != nil {
+			return nil, nil, errors.Wrap(err, "Unable to update the status of the test suite")
+		}
+
+		if len(steps) == 0 || octopus.IsTrue(suite, octopus.TestSuiteFinished) {
+			break
+		}
+		if time.Now().After(deadline) {
+			octopus.MarkErrored(suite, "Timeout", fmt.Sprintf("suite did not finish within %s", cmd.opts.Timeout))
+			if _, updateErr := cmd.K8s.Octopus().UpdateTestSuiteStatus(suite); updateErr != nil {
+				return nil, nil, errors.Wrapf(updateErr, "Unable to mark test suite '%s' as errored after timing out", suiteName)
+			}
+			return nil, nil, fmt.Errorf("Timed out after %s waiting for test suite '%s' to finish", cmd.opts.Timeout, suiteName)
+		}
+
+		time.Sleep(backoff)
+		if backoff < maxBackoff {
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+
+	result, err := cmd.collectResults(suiteName, suite, testDuration)
+	return result, suite, err
+}
+
+// collectResults fetches the pod logs for every executed test and assembles the final report.
+// testDuration holds each test's elapsed time, measured once it reached a terminal state, for
+// the JUnit report.
+func (cmd *command) collectResults(suiteName string, suite *oct.ClusterTestSuite, testDuration map[string]time.Duration) (*suiteResult, error) {
+	result := &suiteResult{Suite: suiteName}
+	for _, tr := range suite.Status.Results {
+		log, err := cmd.fetchTestLog(suiteName, tr.Name, tr.Namespace)
+		if err != nil {
+			log = fmt.Sprintf("unable to fetch logs: %s", err)
+		}
+
+		result.Tests = append(result.Tests, testResult{
+			Name:     tr.Name,
+			Status:   tr.Status,
+			Log:      log,
+			Duration: testDuration[tr.Name],
+		})
+		result.Total++
+		if tr.Status != statusSuccess {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// fetchTestLog retrieves the logs of the pod that executed the given test definition.
+func (cmd *command) fetchTestLog(suiteName, testName, namespace string) (string, error) {
+	pods, err := cmd.K8s.Static().CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("testing.kyma-project.io/def-name=%s,testing.kyma-project.io/suite-name=%s", testName, suiteName),
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no pod found for test '%s'", testName)
+	}
+
+	pod := pods.Items[0]
+	req := cmd.K8s.Static().CoreV1().Pods(namespace).GetLogs(pod.Name, &v1.PodLogOptions{})
+	stream, err := req.Stream()
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	data, err := ioutil.ReadAll(stream)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
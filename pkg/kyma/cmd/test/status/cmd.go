@@ -0,0 +1,129 @@
+package status
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	oct "github.com/kyma-incubator/octopus/pkg/apis/testing/v1alpha1"
+	"github.com/kyma-project/cli/internal/kube"
+	"github.com/kyma-project/cli/pkg/api/octopus"
+	"github.com/kyma-project/cli/pkg/kyma/core"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type command struct {
+	opts *options
+	core.Command
+}
+
+func NewCmd(o *options) *cobra.Command {
+	cmd := command{
+		Command: core.Command{Options: o.Options},
+		opts:    o,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "status <test-suite>",
+		Short: "Shows the status of a Kyma cluster test suite",
+		Long: `Shows the condition table of a ClusterTestSuite.
+
+Use --watch to tail condition transitions as they happen, or --condition to check whether a
+specific condition already reached a given status, which makes it easy to script against, e.g.:
+
+	until kyma test status foo --condition=TestSuiteFinished=True; do sleep 5; done
+`,
+		RunE: func(_ *cobra.Command, args []string) error { return cmd.Run(args) },
+	}
+
+	cobraCmd.Flags().BoolVarP(&o.Watch, "watch", "w", false, "Tail condition transitions until the suite finishes.")
+	cobraCmd.Flags().StringVar(&o.Condition, "condition", "", "Exit successfully only if the given condition already reached the given status, e.g. --condition=TestSuiteFinished=True.")
+	return cobraCmd
+}
+
+func (cmd *command) Run(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("Exactly one test suite name is required")
+	}
+	suiteName := args[0]
+
+	var err error
+	if cmd.K8s, err = kube.NewFromConfig("", cmd.KubeconfigPath); err != nil {
+		return errors.Wrap(err, "Could not initialize the Kubernetes client. Make sure your kubeconfig is valid.")
+	}
+
+	if cmd.opts.Watch {
+		return cmd.watch(suiteName)
+	}
+
+	suite, err := cmd.K8s.Octopus().GetTestSuite(suiteName)
+	if err != nil {
+		return errors.Wrapf(err, "Unable to get the status of test suite '%s'", suiteName)
+	}
+	printConditions(suite)
+
+	if cmd.opts.Condition == "" {
+		return nil
+	}
+
+	condType, condStatus, err := parseCondition(cmd.opts.Condition)
+	if err != nil {
+		return err
+	}
+	if !conditionMatches(suite, condType, condStatus) {
+		return fmt.Errorf("Condition '%s=%s' not yet reached for test suite '%s'", condType, condStatus, suiteName)
+	}
+	return nil
+}
+
+// watch polls the suite and prints every condition transition until the suite finishes.
+func (cmd *command) watch(suiteName string) error {
+	last := map[octopus.ConditionType]octopus.ConditionStatus{}
+	const pollInterval = 2 * time.Second
+
+	for {
+		suite, err := cmd.K8s.Octopus().GetTestSuite(suiteName)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to get the status of test suite '%s'", suiteName)
+		}
+
+		for _, c := range suite.Status.Conditions {
+			ct := octopus.ConditionType(c.Type)
+			cs := octopus.ConditionStatus(c.Status)
+			if last[ct] != cs {
+				fmt.Printf("%s\t%s -> %s\t%s\n", time.Now().Format(time.RFC3339), ct, cs, c.Message)
+				last[ct] = cs
+			}
+		}
+
+		if octopus.IsTrue(suite, octopus.TestSuiteFinished) {
+			return nil
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func printConditions(suite *oct.ClusterTestSuite) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tSTATUS\tREASON\tMESSAGE")
+	for _, c := range suite.Status.Conditions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Type, c.Status, c.Reason, c.Message)
+	}
+	w.Flush()
+}
+
+func conditionMatches(suite *oct.ClusterTestSuite, t octopus.ConditionType, status octopus.ConditionStatus) bool {
+	c, found := octopus.GetCondition(suite, t)
+	return found && c.Status == status
+}
+
+func parseCondition(s string) (octopus.ConditionType, octopus.ConditionStatus, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Invalid --condition value '%s', expected format Type=Status", s)
+	}
+	return octopus.ConditionType(parts[0]), octopus.ConditionStatus(parts[1]), nil
+}
@@ -0,0 +1,14 @@
+package status
+
+import "github.com/kyma-project/cli/pkg/kyma/core"
+
+type options struct {
+	*core.Options
+	Watch     bool
+	Condition string
+}
+
+// NewOptions creates options with default values
+func NewOptions(o *core.Options) *options {
+	return &options{Options: o}
+}
@@ -0,0 +1,120 @@
+// Package steps provides a condition-based, retryable step runner, inspired by the retry
+// pattern used in ARO's pkg/util/steps.
+package steps
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Action is a step that runs once and either succeeds or fails.
+type Action func() error
+
+// Condition is a step that is retried until it reports success, a hard failure, or the step's
+// retry budget is exhausted. ok reports success. retry tells the Runner whether the failure is
+// transient ("call me again") or hard ("abort now").
+type Condition func() (ok bool, retry bool, err error)
+
+// Step describes a single unit of work executed by a Runner. Exactly one of Action or Condition
+// must be set.
+type Step struct {
+	// Name identifies the step in errors and in the StepResult it produces.
+	Name string
+	// Action runs the step exactly once. Mutually exclusive with Condition.
+	Action Action
+	// Condition runs the step until it reports success or a hard failure. Mutually exclusive with Action.
+	Condition Condition
+	// Timeout bounds how long a Condition step may be retried. Zero means no timeout.
+	Timeout time.Duration
+	// MaxRetries bounds how many failed attempts a Condition step may make: the step fails once
+	// its condition has failed MaxRetries times, so it runs at most MaxRetries times. Zero means
+	// no limit.
+	MaxRetries int
+	// Backoff is the delay before the first retry of a Condition step. It doubles after every
+	// attempt, capped at MaxBackoff. Zero defaults to one second.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries. Zero defaults to 30 seconds.
+	MaxBackoff time.Duration
+}
+
+// StepResult reports the outcome of a single Step run.
+type StepResult struct {
+	Name    string
+	Retries int
+	Err     error
+}
+
+// Runner executes a sequence of steps in order, retrying Condition steps according to their own
+// timeout and backoff settings, and stops at the first step that fails for good.
+type Runner struct {
+	Steps []Step
+}
+
+// Run executes all steps in order. It returns the result of every step that ran, including the
+// failing one, so callers can inspect retry counts and the last error even on failure.
+func (r *Runner) Run() ([]StepResult, error) {
+	results := make([]StepResult, 0, len(r.Steps))
+	for _, s := range r.Steps {
+		result, err := s.run()
+		results = append(results, result)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+func (s *Step) run() (StepResult, error) {
+	result := StepResult{Name: s.Name}
+
+	if s.Action != nil {
+		result.Err = s.Action()
+		return result, result.Err
+	}
+
+	backoff := s.Backoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	maxBackoff := s.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+
+	var deadline time.Time
+	if s.Timeout > 0 {
+		deadline = time.Now().Add(s.Timeout)
+	}
+
+	for {
+		ok, retry, err := s.Condition()
+		if ok {
+			return result, nil
+		}
+		if err == nil {
+			err = errors.Errorf("condition for step '%s' was not satisfied", s.Name)
+		}
+		result.Err = err
+
+		if !retry {
+			return result, result.Err
+		}
+
+		result.Retries++
+		if s.MaxRetries > 0 && result.Retries >= s.MaxRetries {
+			return result, errors.Wrapf(result.Err, "step '%s' failed after %d retries", s.Name, result.Retries)
+		}
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return result, errors.Wrapf(result.Err, "step '%s' timed out after %d retries", s.Name, result.Retries)
+		}
+
+		time.Sleep(backoff)
+		if backoff < maxBackoff {
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+		}
+	}
+}
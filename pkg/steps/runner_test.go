@@ -0,0 +1,122 @@
+package steps
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunnerRun(t *testing.T) {
+	t.Run("action succeeds", func(t *testing.T) {
+		r := Runner{Steps: []Step{
+			{Name: "ok", Action: func() error { return nil }},
+		}}
+
+		results, err := r.Run()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(results) != 1 || results[0].Err != nil {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("hard failure stops immediately", func(t *testing.T) {
+		calls := 0
+		r := Runner{Steps: []Step{
+			{
+				Name:    "fails-hard",
+				Backoff: time.Millisecond,
+				Condition: func() (bool, bool, error) {
+					calls++
+					return false, false, errors.New("boom")
+				},
+			},
+		}}
+
+		results, err := r.Run()
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if calls != 1 {
+			t.Fatalf("expected the condition to run exactly once, ran %d times", calls)
+		}
+		if len(results) != 1 || results[0].Retries != 0 {
+			t.Fatalf("unexpected results: %+v", results)
+		}
+	})
+
+	t.Run("retries then succeeds", func(t *testing.T) {
+		calls := 0
+		r := Runner{Steps: []Step{
+			{
+				Name:       "eventually-ok",
+				Backoff:    time.Millisecond,
+				MaxRetries: 5,
+				Condition: func() (bool, bool, error) {
+					calls++
+					if calls < 3 {
+						return false, true, errors.New("not yet")
+					}
+					return true, false, nil
+				},
+			},
+		}}
+
+		results, err := r.Run()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 calls, got %d", calls)
+		}
+		if results[0].Retries != 2 {
+			t.Fatalf("expected 2 retries, got %d", results[0].Retries)
+		}
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		calls := 0
+		r := Runner{Steps: []Step{
+			{
+				Name:       "never-ok",
+				Backoff:    time.Millisecond,
+				MaxRetries: 3,
+				Condition: func() (bool, bool, error) {
+					calls++
+					return false, true, errors.New("still broken")
+				},
+			},
+		}}
+
+		results, err := r.Run()
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 calls, got %d", calls)
+		}
+		if results[0].Retries != 3 {
+			t.Fatalf("expected 3 retries, got %d", results[0].Retries)
+		}
+	})
+
+	t.Run("stops at the first failing step", func(t *testing.T) {
+		var secondRan bool
+		r := Runner{Steps: []Step{
+			{Name: "first", Action: func() error { return errors.New("nope") }},
+			{Name: "second", Action: func() error { secondRan = true; return nil }},
+		}}
+
+		results, err := r.Run()
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if secondRan {
+			t.Fatal("second step should not have run after the first one failed")
+		}
+		if len(results) != 1 {
+			t.Fatalf("expected only the failing step's result, got %+v", results)
+		}
+	})
+}